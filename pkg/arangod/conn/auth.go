@@ -0,0 +1,151 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package conn
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AuthProvider supplies the Authorization header used on every request made through a
+// Connection.
+type AuthProvider interface {
+	// AuthorizationHeader returns the value to set on the Authorization header, e.g.
+	// "bearer <jwt>". An empty string means no Authorization header is added.
+	AuthorizationHeader(ctx context.Context) (string, error)
+	// Invalidate discards any cached credentials, forcing the next call to
+	// AuthorizationHeader to obtain a fresh one. Connection calls this after a request
+	// comes back with status 401, so that providers backed by expiring credentials heal
+	// without the caller having to rebuild the Connection.
+	Invalidate()
+}
+
+type staticAuthProvider struct {
+	header string
+}
+
+// NewStaticAuthProvider returns an AuthProvider that always returns the given header
+// value, reproducing the previous behavior of passing a pre-baked
+// "bearer <jwt_token>" string as ConnectionConfig.Auth.
+func NewStaticAuthProvider(header string) AuthProvider {
+	return staticAuthProvider{header: header}
+}
+
+func (s staticAuthProvider) AuthorizationHeader(context.Context) (string, error) {
+	return s.header, nil
+}
+
+func (s staticAuthProvider) Invalidate() {}
+
+// fixedHeader marks staticAuthProvider as a fixedHeaderProvider (see conn.go): its header
+// never changes, so Execute skips buffering the request body for a 401 retry that could
+// never succeed.
+func (s staticAuthProvider) fixedHeader() {}
+
+// Token is a bearer token together with the time at which it stops being valid.
+type Token struct {
+	// Header is the full Authorization header value, e.g. "Bearer <token>".
+	Header string
+	// ExpiresAt is the time at which Header stops being valid. The zero value means the
+	// token never expires.
+	ExpiresAt time.Time
+}
+
+// TokenSource produces a fresh Token, e.g. by calling out to an identity provider.
+type TokenSource interface {
+	Token(ctx context.Context) (Token, error)
+}
+
+// expiryLeeway is subtracted from a Token's ExpiresAt so a refresh is triggered slightly
+// before the token actually expires, to absorb request latency and clock skew.
+const expiryLeeway = 10 * time.Second
+
+// cachedAuthProvider caches the Token produced by a TokenSource until it is close to
+// expiry, coalescing concurrent refreshes behind a single in-flight TokenSource call.
+type cachedAuthProvider struct {
+	source TokenSource
+
+	mu       sync.Mutex
+	token    Token
+	inflight chan struct{}
+}
+
+// NewCachedAuthProvider returns an AuthProvider that calls source to obtain a Token and
+// caches it until ExpiresAt (minus a small leeway), refreshing on demand behind a
+// singleflight so concurrent callers never issue more than one in-flight refresh.
+func NewCachedAuthProvider(source TokenSource) AuthProvider {
+	return &cachedAuthProvider{source: source}
+}
+
+func (p *cachedAuthProvider) AuthorizationHeader(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	if token, ok := p.validToken(); ok {
+		p.mu.Unlock()
+		return token.Header, nil
+	}
+
+	if inflight := p.inflight; inflight != nil {
+		p.mu.Unlock()
+		select {
+		case <-inflight:
+			return p.AuthorizationHeader(ctx)
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	inflight := make(chan struct{})
+	p.inflight = inflight
+	p.mu.Unlock()
+
+	token, err := p.source.Token(ctx)
+
+	p.mu.Lock()
+	p.inflight = nil
+	if err == nil {
+		p.token = token
+	}
+	p.mu.Unlock()
+	close(inflight)
+
+	if err != nil {
+		return "", err
+	}
+	return token.Header, nil
+}
+
+// validToken returns the cached token if it is still valid. Callers must hold p.mu.
+func (p *cachedAuthProvider) validToken() (Token, bool) {
+	if p.token.Header == "" {
+		return Token{}, false
+	}
+	if p.token.ExpiresAt.IsZero() {
+		return p.token, true
+	}
+	return p.token, time.Now().Add(expiryLeeway).Before(p.token.ExpiresAt)
+}
+
+func (p *cachedAuthProvider) Invalidate() {
+	p.mu.Lock()
+	p.token = Token{}
+	p.mu.Unlock()
+}
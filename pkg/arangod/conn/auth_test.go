@@ -0,0 +1,121 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package conn
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingTokenSource blocks until release is closed before returning token, and counts
+// how many times Token was actually called.
+type blockingTokenSource struct {
+	token   Token
+	release chan struct{}
+	calls   int32
+}
+
+func (s *blockingTokenSource) Token(ctx context.Context) (Token, error) {
+	atomic.AddInt32(&s.calls, 1)
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return Token{}, ctx.Err()
+	}
+	return s.token, nil
+}
+
+func TestCachedAuthProviderCoalescesConcurrentRefreshes(t *testing.T) {
+	source := &blockingTokenSource{
+		token:   Token{Header: "Bearer abc", ExpiresAt: time.Now().Add(time.Hour)},
+		release: make(chan struct{}),
+	}
+	provider := NewCachedAuthProvider(source)
+
+	const callers = 5
+	results := make(chan string, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			header, err := provider.AuthorizationHeader(context.Background())
+			if err != nil {
+				t.Errorf("AuthorizationHeader returned error: %v", err)
+			}
+			results <- header
+		}()
+	}
+
+	// Give every goroutine a chance to join the in-flight refresh before it completes.
+	time.Sleep(50 * time.Millisecond)
+	close(source.release)
+
+	for i := 0; i < callers; i++ {
+		select {
+		case header := <-results:
+			if header != "Bearer abc" {
+				t.Fatalf("expected header %q, got %q", "Bearer abc", header)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for AuthorizationHeader")
+		}
+	}
+
+	if got := atomic.LoadInt32(&source.calls); got != 1 {
+		t.Fatalf("expected exactly 1 call to TokenSource.Token, got %d", got)
+	}
+}
+
+// TestCachedAuthProviderRespectsCallerContext guards against a regression where a caller
+// piggybacking on someone else's in-flight refresh ignored its own ctx and blocked past its
+// deadline instead of returning ctx.Err().
+func TestCachedAuthProviderRespectsCallerContext(t *testing.T) {
+	source := &blockingTokenSource{
+		token:   Token{Header: "Bearer abc"},
+		release: make(chan struct{}),
+	}
+	defer close(source.release)
+
+	provider := NewCachedAuthProvider(source)
+
+	// Start the in-flight refresh with a long-lived context.
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		_, _ = provider.AuthorizationHeader(context.Background())
+	}()
+	<-started
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := provider.AuthorizationHeader(ctx)
+	elapsed := time.Since(start)
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("AuthorizationHeader blocked past its own context deadline: took %v", elapsed)
+	}
+}
@@ -21,6 +21,7 @@
 package conn
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -35,13 +36,72 @@ type Connection interface {
 type connection struct {
 	client *http.Client
 
-	auth *string
+	auth AuthProvider
 
 	host string
+
+	observer Observer
+}
+
+// fixedHeaderProvider is implemented by AuthProviders whose AuthorizationHeader value
+// never changes (currently: only staticAuthProvider), so Invalidate can never make a 401
+// retry succeed. Execute checks for it to avoid buffering the whole request body into
+// memory for the common case of a static bearer token.
+type fixedHeaderProvider interface {
+	fixedHeader()
 }
 
 func (c connection) Execute(ctx context.Context, method string, endpoint string, body io.Reader) (io.ReadCloser, int, error) {
-	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", c.host, endpoint), body)
+	if c.auth == nil {
+		return c.execute(ctx, method, endpoint, body)
+	}
+	if _, ok := c.auth.(fixedHeaderProvider); ok {
+		return c.execute(ctx, method, endpoint, body)
+	}
+
+	// Buffer the body so it can be resent if the first attempt is rejected with 401 and
+	// needs to be retried with a refreshed Authorization header.
+	var buf []byte
+	if body != nil {
+		var err error
+		buf, err = io.ReadAll(body)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	b, status, err := c.execute(ctx, method, endpoint, bytes.NewReader(buf))
+	if err != nil || status != http.StatusUnauthorized {
+		return b, status, err
+	}
+	if b != nil {
+		b.Close()
+	}
+
+	c.auth.Invalidate()
+	c.observer.Metrics.RequestRetried(method, endpoint)
+	return c.execute(ctx, method, endpoint, bytes.NewReader(buf))
+}
+
+func (c connection) execute(ctx context.Context, method string, endpoint string, body io.Reader) (io.ReadCloser, int, error) {
+	ctx, endSpan := c.observer.Tracer.StartSpan(ctx, method, endpoint)
+	start := time.Now()
+	var (
+		statusCode int
+		err        error
+	)
+	defer func() {
+		endSpan(statusCode, err)
+		if err != nil {
+			c.observer.Logger.Error(err, "request failed", "method", method, "endpoint", endpoint)
+			c.observer.Metrics.RequestFailed(method, endpoint, time.Since(start))
+		} else {
+			c.observer.Metrics.RequestCompleted(method, endpoint, statusCode, time.Since(start))
+		}
+	}()
+
+	var req *http.Request
+	req, err = http.NewRequest(method, fmt.Sprintf("%s%s", c.host, endpoint), body)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -49,13 +109,23 @@ func (c connection) Execute(ctx context.Context, method string, endpoint string,
 	req = req.WithContext(ctx)
 
 	if a := c.auth; a != nil {
-		req.Header.Add("Authorization", *a)
+		var header string
+		header, err = a.AuthorizationHeader(ctx)
+		if err != nil {
+			err = fmt.Errorf("failed to obtain authorization header: %w", err)
+			return nil, 0, err
+		}
+		if header != "" {
+			req.Header.Add("Authorization", header)
+		}
 	}
 
-	resp, err := c.client.Do(req)
+	var resp *http.Response
+	resp, err = c.client.Do(req)
 	if err != nil {
 		return nil, 0, err
 	}
+	statusCode = resp.StatusCode
 
 	if b := resp.Body; b != nil {
 		return b, resp.StatusCode, nil
@@ -68,16 +138,31 @@ func (c connection) Execute(ctx context.Context, method string, endpoint string,
 type ConnectionConfig struct {
 	// host is address to a server.
 	Host string
-	// auth is a pure jwt bearer token: "bearer <jwt_token>"
-	Auth *string
+	// Auth provides the Authorization header added to every request. Use
+	// NewStaticAuthProvider to reproduce the previous "bearer <jwt_token>" behavior, or one
+	// of the other AuthProvider implementations for credentials that expire and need
+	// refreshing.
+	Auth AuthProvider
 	// ConnWrapper wraps original network connection with an additional functionality provided by a caller.
 	ConnWrapper TransportConnWrap
+	// TLS configures TLS and mTLS when Host uses the https scheme. Leave it at its zero
+	// value to use the Go default transport TLS behavior.
+	TLS TLSConfig
+	// Observer provides metrics, tracing and logging hooks for requests made through the
+	// Connection. Leave it at its zero value to disable observability.
+	Observer Observer
 }
 
-func NewConnection(c ConnectionConfig) Connection {
+func NewConnection(c ConnectionConfig) (Connection, error) {
+	tlsConfig, err := c.TLS.toTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	return connection{
-		auth: c.Auth,
-		host: c.Host,
+		auth:     c.Auth,
+		host:     c.Host,
+		observer: c.Observer.orNoop(),
 		client: &http.Client{
 			Transport: &http.Transport{
 				Proxy:                 http.ProxyFromEnvironment,
@@ -86,10 +171,11 @@ func NewConnection(c ConnectionConfig) Connection {
 				IdleConnTimeout:       100 * time.Millisecond,
 				TLSHandshakeTimeout:   10 * time.Second,
 				ExpectContinueTimeout: 1 * time.Second,
+				TLSClientConfig:       tlsConfig,
 			},
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				return http.ErrUseLastResponse
 			},
 		},
-	}
+	}, nil
 }
@@ -0,0 +1,132 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package conn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// OAuth2AuthStyle selects how client credentials are sent to the token endpoint.
+type OAuth2AuthStyle int
+
+const (
+	// OAuth2AuthStyleBasic sends the client ID and secret as HTTP Basic auth. This is the
+	// default.
+	OAuth2AuthStyleBasic OAuth2AuthStyle = iota
+	// OAuth2AuthStylePost sends the client ID and secret as form fields in the request body.
+	OAuth2AuthStylePost
+)
+
+// OAuth2ClientCredentialsConfig configures an AuthProvider that performs the OAuth2
+// "client_credentials" grant against TokenURL.
+type OAuth2ClientCredentialsConfig struct {
+	// TokenURL is the OAuth2 token endpoint.
+	TokenURL string
+	// ClientID is the OAuth2 client identifier.
+	ClientID string
+	// ClientSecret is the OAuth2 client secret.
+	ClientSecret string
+	// Scopes, if set, is sent as a space-separated "scope" form field.
+	Scopes []string
+	// AuthStyle selects how ClientID/ClientSecret are sent. Defaults to OAuth2AuthStyleBasic.
+	AuthStyle OAuth2AuthStyle
+	// HTTPClient is used to call TokenURL. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// NewOAuth2ClientCredentialsProvider returns an AuthProvider that obtains and caches an
+// access token via the OAuth2 client credentials grant, refreshing it shortly before it
+// expires.
+func NewOAuth2ClientCredentialsProvider(cfg OAuth2ClientCredentialsConfig) AuthProvider {
+	return NewCachedAuthProvider(oauth2ClientCredentialsSource{cfg: cfg})
+}
+
+type oauth2ClientCredentialsSource struct {
+	cfg OAuth2ClientCredentialsConfig
+}
+
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (s oauth2ClientCredentialsSource) Token(ctx context.Context) (Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(s.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.cfg.Scopes, " "))
+	}
+	if s.cfg.AuthStyle == OAuth2AuthStylePost {
+		form.Set("client_id", s.cfg.ClientID)
+		form.Set("client_secret", s.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if s.cfg.AuthStyle != OAuth2AuthStylePost {
+		req.SetBasicAuth(s.cfg.ClientID, s.cfg.ClientSecret)
+	}
+
+	httpClient := s.cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Token{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Token{}, fmt.Errorf("oauth2: token endpoint %q returned status %d", s.cfg.TokenURL, resp.StatusCode)
+	}
+
+	var body oauth2TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Token{}, fmt.Errorf("oauth2: failed to decode token response: %w", err)
+	}
+	if body.AccessToken == "" {
+		return Token{}, fmt.Errorf("oauth2: token endpoint %q returned no access_token", s.cfg.TokenURL)
+	}
+
+	tokenType := body.TokenType
+	if tokenType == "" {
+		tokenType = "Bearer"
+	}
+
+	token := Token{Header: fmt.Sprintf("%s %s", tokenType, body.AccessToken)}
+	if body.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
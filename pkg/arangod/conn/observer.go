@@ -0,0 +1,90 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package conn
+
+import (
+	"context"
+	"time"
+)
+
+// MetricsRecorder records quantitative signals about requests made through a Connection.
+type MetricsRecorder interface {
+	// RequestCompleted is called once per attempt that received a response, including the
+	// 401 retry attempt, with the final status code and how long the attempt took.
+	RequestCompleted(method, endpoint string, statusCode int, duration time.Duration)
+	// RequestFailed is called instead of RequestCompleted when an attempt did not receive a
+	// response at all, e.g. because of a network error.
+	RequestFailed(method, endpoint string, duration time.Duration)
+	// RequestRetried is called once for every retried attempt (currently: the 401 retry).
+	RequestRetried(method, endpoint string)
+}
+
+// Tracer starts a span around a single request made through a Connection.
+type Tracer interface {
+	// StartSpan starts a span for the given request, returning a context carrying it (so
+	// that further calls made with that context chain as child spans) and a function to be
+	// called once the attempt finishes, reporting its status code and error, if any.
+	StartSpan(ctx context.Context, method, endpoint string) (context.Context, func(statusCode int, err error))
+}
+
+// Logger is the minimal structured logger used by conn.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Error(err error, msg string, keysAndValues ...interface{})
+}
+
+// Observer bundles the observability hooks a Connection reports through. Each field
+// defaults to a no-op when left nil.
+type Observer struct {
+	Metrics MetricsRecorder
+	Tracer  Tracer
+	Logger  Logger
+}
+
+func (o Observer) orNoop() Observer {
+	if o.Metrics == nil {
+		o.Metrics = noopMetricsRecorder{}
+	}
+	if o.Tracer == nil {
+		o.Tracer = noopTracer{}
+	}
+	if o.Logger == nil {
+		o.Logger = noopLogger{}
+	}
+	return o
+}
+
+type noopMetricsRecorder struct{}
+
+func (noopMetricsRecorder) RequestCompleted(string, string, int, time.Duration) {}
+func (noopMetricsRecorder) RequestFailed(string, string, time.Duration)         {}
+func (noopMetricsRecorder) RequestRetried(string, string)                       {}
+
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, _, _ string) (context.Context, func(int, error)) {
+	return ctx, func(int, error) {}
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...interface{})        {}
+func (noopLogger) Error(error, string, ...interface{}) {}
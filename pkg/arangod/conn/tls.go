@@ -0,0 +1,209 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package conn
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSConfig describes the TLS (and mTLS) settings used when connecting to a server over HTTPS.
+// A zero value disables custom TLS handling and falls back to the Go default transport behavior.
+type TLSConfig struct {
+	// CAFile is a path to a PEM encoded CA bundle used to verify the server certificate.
+	// It is re-read on every handshake (via VerifyConnection, since crypto/tls has no
+	// per-handshake reload hook for Config.RootCAs on the client side), so rotating the
+	// file on disk is picked up automatically.
+	CAFile string
+	// CACertificate is a PEM encoded CA bundle used to verify the server certificate.
+	// Ignored if CAFile is set.
+	CACertificate []byte
+	// CACertPool is a pre-built certificate pool used to verify the server certificate.
+	// Takes precedence over CAFile and CACertificate.
+	CACertPool *x509.CertPool
+
+	// CertFile is a path to a PEM encoded client certificate, used for mTLS.
+	// It is re-read on every handshake, so rotating the file on disk is picked up automatically.
+	CertFile string
+	// KeyFile is a path to a PEM encoded client private key, used for mTLS.
+	// It is re-read on every handshake, so rotating the file on disk is picked up automatically.
+	KeyFile string
+	// Certificate is a PEM encoded client certificate, used for mTLS.
+	// Ignored if CertFile is set.
+	Certificate []byte
+	// Key is a PEM encoded client private key, used for mTLS.
+	// Ignored if KeyFile is set.
+	Key []byte
+	// GetClientCertificate, when set, is called whenever the server requests a client
+	// certificate, taking precedence over CertFile/KeyFile/Certificate/Key. Use it to hand
+	// out certificates produced by a file watcher or another rotation mechanism so that
+	// rotating credentials does not require tearing down the Connection.
+	GetClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
+
+	// InsecureSkipVerify disables server certificate verification. Use with care.
+	InsecureSkipVerify bool
+	// ServerName overrides the server name used for SNI and certificate verification.
+	ServerName string
+	// MinVersion is the minimum TLS version accepted. Defaults to tls.VersionTLS12.
+	MinVersion uint16
+}
+
+// toTLSConfig translates a TLSConfig into a *tls.Config suitable for http.Transport.TLSClientConfig.
+// It returns (nil, nil) when the config is empty, in which case the caller should leave
+// TLSClientConfig unset and use the Go default transport behavior.
+func (c TLSConfig) toTLSConfig() (*tls.Config, error) {
+	if c.isEmpty() {
+		return nil, nil
+	}
+
+	minVersion := c.MinVersion
+	if minVersion == 0 {
+		minVersion = tls.VersionTLS12
+	}
+
+	cfg := &tls.Config{
+		ServerName: c.ServerName,
+		MinVersion: minVersion,
+	}
+
+	if c.CAFile != "" && c.CACertPool == nil {
+		// The file is re-read on every handshake from within VerifyConnection, since
+		// crypto/tls only exposes a reload hook (GetConfigForClient) on the server side.
+		// InsecureSkipVerify disables the stdlib's own (static) verification; the chain is
+		// still fully verified below, just against a freshly loaded CA pool each time.
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyConnection = verifyConnectionAgainstCAFile(c.CAFile)
+	} else {
+		pool, err := c.certPool()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build CA certificate pool: %w", err)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if c.InsecureSkipVerify {
+		// An explicit request to skip verification always wins, including over the
+		// CAFile reload path above.
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyConnection = nil
+	}
+
+	switch {
+	case c.GetClientCertificate != nil:
+		cfg.GetClientCertificate = c.GetClientCertificate
+	case c.CertFile != "" || c.KeyFile != "":
+		cfg.GetClientCertificate = fileClientCertificateLoader(c.CertFile, c.KeyFile)
+	case len(c.Certificate) > 0 || len(c.Key) > 0:
+		cert, err := tls.X509KeyPair(c.Certificate, c.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// isEmpty reports whether the config carries no TLS settings at all, in which case the
+// caller should leave TLSClientConfig unset instead of installing an empty *tls.Config.
+func (c TLSConfig) isEmpty() bool {
+	return c.CAFile == "" &&
+		c.CACertificate == nil &&
+		c.CACertPool == nil &&
+		c.CertFile == "" &&
+		c.KeyFile == "" &&
+		c.Certificate == nil &&
+		c.Key == nil &&
+		c.GetClientCertificate == nil &&
+		!c.InsecureSkipVerify &&
+		c.ServerName == "" &&
+		c.MinVersion == 0
+}
+
+// certPool resolves the effective static CA certificate pool, preferring CACertPool, then
+// CACertificate. CAFile is handled separately, by verifyConnectionAgainstCAFile, since it
+// needs to be re-read on every handshake rather than loaded once.
+func (c TLSConfig) certPool() (*x509.CertPool, error) {
+	switch {
+	case c.CACertPool != nil:
+		return c.CACertPool, nil
+	case len(c.CACertificate) > 0:
+		return certPoolFromPEM(c.CACertificate)
+	default:
+		return nil, nil
+	}
+}
+
+func certPoolFromPEM(pem []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in PEM data")
+	}
+	return pool, nil
+}
+
+// verifyConnectionAgainstCAFile returns a VerifyConnection callback which re-reads caFile
+// from disk on every handshake and verifies the peer's certificate chain against the
+// freshly loaded CA pool, so that rotating the file in place does not require rebuilding
+// the Connection. It must be paired with InsecureSkipVerify, which disables the stdlib's
+// own (static) verification in favor of this one.
+func verifyConnectionAgainstCAFile(caFile string) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA file %q: %w", caFile, err)
+		}
+		pool, err := certPoolFromPEM(pem)
+		if err != nil {
+			return fmt.Errorf("failed to build CA certificate pool from %q: %w", caFile, err)
+		}
+		if len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("no peer certificates presented")
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range cs.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+
+		_, err = cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+			Roots:         pool,
+			DNSName:       cs.ServerName,
+			Intermediates: intermediates,
+		})
+		return err
+	}
+}
+
+// fileClientCertificateLoader returns a GetClientCertificate callback which re-reads
+// certFile/keyFile from disk on every call, so that rotating the files in place (e.g. by
+// a file watcher or a secret-mount refresh) does not require rebuilding the Connection.
+func fileClientCertificateLoader(certFile, keyFile string) func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate from %q/%q: %w", certFile, keyFile, err)
+		}
+		return &cert, nil
+	}
+}
@@ -0,0 +1,100 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package agencystore implements election.Store on top of an ArangoDB agency.
+package agencystore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	driver "github.com/arangodb/go-driver"
+	"github.com/arangodb/go-driver/agency"
+
+	"github.com/arangodb-helper/go-helper/pkg/arangod/election"
+)
+
+const keyTTL = "ttl"
+
+// envelope is the document stored in the agency for a key. The agency has no native
+// key expiry, so the ttl (the expiry time in Unix seconds) is stored as a regular field;
+// nothing removes the key once it passes. The ttl also serves as the compare-and-swap
+// token (in place of a real revision).
+type envelope struct {
+	Value json.RawMessage `json:"value,omitempty"`
+	TTL   int64           `json:"ttl,omitempty"`
+}
+
+// Store implements election.Store on top of an ArangoDB agency.
+type Store struct {
+	cli agency.Agency
+}
+
+// New creates a Store backed by the given agency client.
+func New(cli agency.Agency) *Store {
+	return &Store{cli: cli}
+}
+
+func (s *Store) Get(ctx context.Context, key []string) ([]byte, election.Revision, error) {
+	var result envelope
+	if err := s.cli.ReadKey(ctx, key, &result); err != nil {
+		if agency.IsKeyNotFound(err) {
+			return nil, election.RevisionNotExist, election.ErrKeyNotFound
+		}
+		return nil, election.RevisionNotExist, err
+	}
+	return result.Value, election.Revision(result.TTL), nil
+}
+
+func (s *Store) CompareAndSwap(ctx context.Context, key []string, oldRevision election.Revision, newValue []byte, ttl time.Duration) (election.Revision, error) {
+	trx := agency.NewTransaction("", agency.TransactionOptions{})
+
+	newTTL := time.Now().Add(ttl).Unix()
+	trx.AddKey(agency.NewKeySet(key, envelope{Value: newValue, TTL: newTTL}, 0))
+	if oldRevision == election.RevisionNotExist {
+		trx.AddCondition(key, agency.NewConditionOldEmpty(true))
+	} else {
+		trx.AddCondition(append(key, keyTTL), agency.NewConditionIfEqual(int64(oldRevision)))
+	}
+
+	if err := s.cli.WriteTransaction(ctx, trx); err != nil {
+		if driver.IsPreconditionFailed(err) {
+			return election.RevisionNotExist, election.ErrPreconditionFailed
+		}
+		return election.RevisionNotExist, err
+	}
+
+	return election.Revision(newTTL), nil
+}
+
+func (s *Store) CompareAndDelete(ctx context.Context, key []string, oldRevision election.Revision) error {
+	trx := agency.NewTransaction("", agency.TransactionOptions{})
+	trx.AddCondition(append(key, keyTTL), agency.NewConditionIfEqual(int64(oldRevision)))
+	trx.AddKey(agency.NewKeyDelete(key))
+
+	if err := s.cli.WriteTransaction(ctx, trx); err != nil {
+		if driver.IsPreconditionFailed(err) {
+			return election.ErrPreconditionFailed
+		}
+		return err
+	}
+	return nil
+}
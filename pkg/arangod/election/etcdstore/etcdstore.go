@@ -0,0 +1,156 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package etcdstore implements election.Store on top of an etcd v3 client, using leases
+// for TTL expiry and a transaction comparing ModRevision for compare-and-swap.
+package etcdstore
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/arangodb-helper/go-helper/pkg/arangod/election"
+)
+
+// Store implements election.Store on top of an etcd v3 client.
+type Store struct {
+	cli *clientv3.Client
+
+	mu     sync.Mutex
+	leases map[string]clientv3.LeaseID
+}
+
+// New creates a Store backed by the given etcd v3 client.
+func New(cli *clientv3.Client) *Store {
+	return &Store{cli: cli, leases: make(map[string]clientv3.LeaseID)}
+}
+
+// leaseFor returns a live lease to attach to key's value, reusing and renewing the lease
+// from a previous CompareAndSwap call on this key where possible so that a leader renewing
+// its TTL does not mint (and orphan) a brand-new lease on every call.
+func (s *Store) leaseFor(ctx context.Context, k string, ttl time.Duration) (clientv3.LeaseID, error) {
+	s.mu.Lock()
+	id, ok := s.leases[k]
+	s.mu.Unlock()
+
+	if ok {
+		if _, err := s.cli.KeepAliveOnce(ctx, id); err == nil {
+			return id, nil
+		}
+		// The lease is gone (expired, or revoked elsewhere); fall through and grant a new one.
+	}
+
+	lease, err := s.cli.Grant(ctx, int64(ttl.Round(time.Second)/time.Second))
+	if err != nil {
+		return 0, err
+	}
+	return lease.ID, nil
+}
+
+// releaseLease revokes the lease held for key, if any, e.g. after losing a compare-and-swap
+// race or resigning, so it does not sit around idle until it expires on its own.
+func (s *Store) releaseLease(k string) {
+	s.mu.Lock()
+	id, ok := s.leases[k]
+	delete(s.leases, k)
+	s.mu.Unlock()
+
+	if ok {
+		// Best-effort: if the lease already expired or the client is shutting down, there is
+		// nothing useful to do with the error.
+		_, _ = s.cli.Revoke(context.Background(), id)
+	}
+}
+
+func (s *Store) Get(ctx context.Context, key []string) ([]byte, election.Revision, error) {
+	resp, err := s.cli.Get(ctx, joinKey(key))
+	if err != nil {
+		return nil, election.RevisionNotExist, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, election.RevisionNotExist, election.ErrKeyNotFound
+	}
+	return resp.Kvs[0].Value, election.Revision(resp.Kvs[0].ModRevision), nil
+}
+
+// CompareAndSwap does not implement the Get-the-current-value-on-failure optimization an
+// etcd Txn's Else branch could offer: election.Store's CompareAndSwap only returns a
+// Revision, with no way to carry the current value back to the caller, so a failed CAS here
+// costs callers a separate Get on their next loop iteration, same as the agencystore
+// implementation.
+func (s *Store) CompareAndSwap(ctx context.Context, key []string, oldRevision election.Revision, newValue []byte, ttl time.Duration) (election.Revision, error) {
+	k := joinKey(key)
+
+	leaseID, err := s.leaseFor(ctx, k, ttl)
+	if err != nil {
+		return election.RevisionNotExist, err
+	}
+
+	var cmp clientv3.Cmp
+	if oldRevision == election.RevisionNotExist {
+		cmp = clientv3.Compare(clientv3.ModRevision(k), "=", 0)
+	} else {
+		cmp = clientv3.Compare(clientv3.ModRevision(k), "=", int64(oldRevision))
+	}
+
+	resp, err := s.cli.Txn(ctx).
+		If(cmp).
+		Then(clientv3.OpPut(k, string(newValue), clientv3.WithLease(leaseID))).
+		Commit()
+	if err != nil {
+		return election.RevisionNotExist, err
+	}
+	if !resp.Succeeded {
+		s.releaseLease(k)
+		return election.RevisionNotExist, election.ErrPreconditionFailed
+	}
+
+	s.mu.Lock()
+	s.leases[k] = leaseID
+	s.mu.Unlock()
+
+	return election.Revision(resp.Header.Revision), nil
+}
+
+func (s *Store) CompareAndDelete(ctx context.Context, key []string, oldRevision election.Revision) error {
+	k := joinKey(key)
+
+	resp, err := s.cli.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(k), "=", int64(oldRevision))).
+		Then(clientv3.OpDelete(k)).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return election.ErrPreconditionFailed
+	}
+
+	s.releaseLease(k)
+	return nil
+}
+
+func joinKey(key []string) string {
+	return "/" + strings.Join(key, "/")
+}
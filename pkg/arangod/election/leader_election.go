@@ -0,0 +1,266 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package election implements leader election on top of a pluggable Store. The Store
+// interface abstracts over the compare-and-swap backend, so the election logic itself does
+// not depend on any particular one. See the agencystore sub-package for an ArangoDB agency
+// backed Store, or the etcdstore sub-package for an etcd v3 backed one.
+package election
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+)
+
+// Revision identifies a specific version of the value stored at a Store key. Its zero
+// value, RevisionNotExist, represents the absence of the key.
+type Revision int64
+
+// RevisionNotExist is the Revision of a key that does not exist.
+const RevisionNotExist Revision = 0
+
+// ErrPreconditionFailed is returned by a Store's CompareAndSwap/CompareAndDelete when the
+// expected revision no longer matches the stored value.
+var ErrPreconditionFailed = errors.New("election: precondition failed")
+
+// ErrKeyNotFound is returned by a Store's Get when the requested key does not exist.
+var ErrKeyNotFound = errors.New("election: key not found")
+
+// Store is the minimal key/value backend a LeaderElectionCell needs. Implementations must
+// provide linearizable compare-and-swap semantics on a single key so that only one
+// competing writer can ever win a given revision transition.
+type Store interface {
+	// Get returns the raw value currently stored at key and the Revision identifying it.
+	// ErrKeyNotFound is returned if key does not exist.
+	Get(ctx context.Context, key []string) ([]byte, Revision, error)
+
+	// CompareAndSwap stores newValue at key with the given ttl, but only if the key's
+	// current Revision equals oldRevision (or the key does not exist, if oldRevision is
+	// RevisionNotExist). On success it returns the Revision of the newly stored value.
+	// ErrPreconditionFailed is returned if the comparison fails.
+	CompareAndSwap(ctx context.Context, key []string, oldRevision Revision, newValue []byte, ttl time.Duration) (Revision, error)
+
+	// CompareAndDelete removes key, but only if its current Revision equals oldRevision.
+	// ErrPreconditionFailed is returned if the comparison fails.
+	CompareAndDelete(ctx context.Context, key []string, oldRevision Revision) error
+}
+
+func NewLeaderElectionCell[T comparable](key []string, ttl time.Duration, opts ...Option[T]) *LeaderElectionCell[T] {
+	l := &LeaderElectionCell[T]{
+		lastRevision: RevisionNotExist,
+		leading:      false,
+		key:          key,
+		ttl:          ttl,
+		observer:     Observer{}.orNoop(),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+type LeaderElectionCell[T comparable] struct {
+	lastRevision Revision
+	leading      bool
+	key          []string
+	ttl          time.Duration
+	observer     Observer
+}
+
+type leaderStruct[T comparable] struct {
+	Data      T     `json:"data,omitempty"`
+	ExpiresAt int64 `json:"expiresAt,omitempty"`
+}
+
+// LeaderCondition reports whether the value currently stored in a Store matches the value
+// the condition was created for.
+type LeaderCondition[T comparable] func(ctx context.Context, store Store) (bool, error)
+
+// GetLeaderCondition creates a condition which is resolved to true only if the current
+// value in store equals the provided dataValue.
+func (l *LeaderElectionCell[T]) GetLeaderCondition(dataValue T) LeaderCondition[T] {
+	return func(ctx context.Context, store Store) (bool, error) {
+		current, err := l.Read(ctx, store)
+		if err != nil {
+			return false, err
+		}
+		return reflect.DeepEqual(current, dataValue), nil
+	}
+}
+
+func (l *LeaderElectionCell[T]) tryBecomeLeader(ctx context.Context, store Store, value T, oldRevision Revision) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	data, err := json.Marshal(leaderStruct[T]{Data: value, ExpiresAt: time.Now().Add(l.ttl).Unix()})
+	if err != nil {
+		return err
+	}
+
+	newRevision, err := store.CompareAndSwap(ctx, l.key, oldRevision, data, l.ttl)
+	if err != nil {
+		if errors.Is(err, ErrPreconditionFailed) {
+			l.observer.Metrics.UpdatePreconditionFailed()
+		} else {
+			l.observer.Logger.Error(err, "failed to become leader")
+		}
+		return err
+	}
+
+	wasLeading := l.leading
+	l.lastRevision = newRevision
+	l.leading = true
+	l.observer.Metrics.IsLeader(true)
+	if !wasLeading {
+		l.observer.Metrics.LeaderAcquired()
+		l.observer.Logger.Debug("became leader")
+	}
+
+	return nil
+}
+
+func (l *LeaderElectionCell[T]) readCell(ctx context.Context, store Store) (leaderStruct[T], Revision, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result leaderStruct[T]
+	data, revision, err := store.Get(ctx, l.key)
+	if err != nil {
+		return result, RevisionNotExist, err
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, RevisionNotExist, err
+	}
+	return result, revision, nil
+}
+
+func (l *LeaderElectionCell[T]) Read(ctx context.Context, store Store) (T, error) {
+	result, _, err := l.readCell(ctx, store)
+	if err != nil {
+		var def T
+		if errors.Is(err, ErrKeyNotFound) {
+			return def, nil
+		}
+		return def, err
+	}
+	return result.Data, nil
+}
+
+// Update checks the current leader cell and if no leader is present
+// it tries to put itself in there. Will return the value currently present,
+// whether we are leader and a duration after which Update should be called again.
+func (l *LeaderElectionCell[T]) Update(ctx context.Context, store Store, value T) (T, bool, time.Duration, error) {
+	const minUpdateDelay = time.Millisecond * 500
+	for {
+		assumeEmpty := false
+		result, revision, err := l.readCell(ctx, store)
+		if err != nil {
+			if errors.Is(err, ErrKeyNotFound) {
+				assumeEmpty = true
+				goto tryLeaderElection
+			}
+			var def T
+			return def, false, 0, err
+		}
+
+		{
+			now := time.Now()
+			if result.ExpiresAt < now.Unix() {
+				// expired, try to become leader
+				if l.leading {
+					l.observer.Metrics.IsLeader(false)
+					l.observer.Metrics.LeaderLost()
+				}
+				l.lastRevision = revision
+				l.leading = false
+				goto tryLeaderElection
+			}
+
+			if result.ExpiresAt > now.Unix() && !l.leading && l.lastRevision == RevisionNotExist {
+				// curr leader is not expired yet, but we are not initialized yet, so initialize manually:
+				l.lastRevision = revision
+				l.leading = reflect.DeepEqual(result.Data, value)
+			}
+
+			if revision == l.lastRevision && l.leading {
+				// try to update the ttl
+				goto tryLeaderElection
+			} else {
+				// some new leader has been established
+				wasLeading := l.leading
+				l.lastRevision = revision
+				l.leading = false
+				l.observer.Metrics.IsLeader(false)
+				l.observer.Metrics.TTLRemainingSeconds(time.Unix(result.ExpiresAt, 0).Sub(now).Seconds())
+				if wasLeading {
+					l.observer.Metrics.LeaderLost()
+					l.observer.Logger.Debug("lost leadership")
+				}
+				updateDelay := time.Unix(result.ExpiresAt, 0).Sub(now)
+				if updateDelay < minUpdateDelay {
+					// throttle
+					updateDelay = minUpdateDelay
+				}
+				return result.Data, false, updateDelay, nil
+			}
+		}
+
+	tryLeaderElection:
+		var def T
+		oldRevision := RevisionNotExist
+		if !assumeEmpty {
+			oldRevision = revision
+		}
+		if err := l.tryBecomeLeader(ctx, store, value, oldRevision); err == nil {
+			return value, true, l.ttl / 2, nil
+		} else if !errors.Is(err, ErrPreconditionFailed) {
+			return def, false, 0, err
+		} else if ctx.Err() != nil {
+			return def, false, 0, err
+		} else {
+			time.Sleep(minUpdateDelay)
+		}
+	}
+}
+
+// Resign tries to resign leadership. If error is returned, caller should retry
+func (l *LeaderElectionCell[T]) Resign(ctx context.Context, store Store) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// delete the key with precondition that the revision is as expected
+	if !l.leading {
+		return nil
+	}
+	err := store.CompareAndDelete(ctx, l.key, l.lastRevision)
+	if err != nil && !errors.Is(err, ErrPreconditionFailed) {
+		// still leading, so a retry attempts the delete again
+		l.observer.Logger.Error(err, "failed to resign leadership")
+		return err
+	}
+	// deleted, or ErrPreconditionFailed: we're no longer the leader
+	l.leading = false
+	l.observer.Metrics.IsLeader(false)
+	l.observer.Metrics.LeaderLost()
+	return nil
+}
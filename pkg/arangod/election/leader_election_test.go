@@ -0,0 +1,171 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package election
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memStore is an in-memory election.Store used for tests. It is safe for concurrent use.
+type memStore struct {
+	mu       sync.Mutex
+	value    []byte
+	revision Revision
+	exists   bool
+}
+
+func (s *memStore) Get(_ context.Context, _ []string) ([]byte, Revision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.exists {
+		return nil, RevisionNotExist, ErrKeyNotFound
+	}
+	return s.value, s.revision, nil
+}
+
+func (s *memStore) CompareAndSwap(_ context.Context, _ []string, oldRevision Revision, newValue []byte, _ time.Duration) (Revision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := RevisionNotExist
+	if s.exists {
+		current = s.revision
+	}
+	if current != oldRevision {
+		return RevisionNotExist, ErrPreconditionFailed
+	}
+
+	s.revision++
+	s.value = newValue
+	s.exists = true
+	return s.revision, nil
+}
+
+func (s *memStore) CompareAndDelete(_ context.Context, _ []string, oldRevision Revision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.exists || s.revision != oldRevision {
+		return ErrPreconditionFailed
+	}
+	s.exists = false
+	s.value = nil
+	return nil
+}
+
+// forceValue directly overwrites the stored value and bumps the revision, simulating
+// another process winning a compare-and-swap without going through this store's own API.
+func (s *memStore) forceValue(t *testing.T, value string) {
+	t.Helper()
+	data, err := json.Marshal(leaderStruct[string]{Data: value, ExpiresAt: time.Now().Add(time.Minute).Unix()})
+	if err != nil {
+		t.Fatalf("failed to marshal leader struct: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.value = data
+	s.revision++
+	s.exists = true
+}
+
+func TestUpdateBecomesLeaderWhenEmpty(t *testing.T) {
+	store := &memStore{}
+	cell := NewLeaderElectionCell[string]([]string{"leader"}, time.Minute)
+
+	value, isLeader, _, err := cell.Update(context.Background(), store, "node-1")
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if !isLeader {
+		t.Fatalf("expected to become leader on an empty key")
+	}
+	if value != "node-1" {
+		t.Fatalf("expected value %q, got %q", "node-1", value)
+	}
+}
+
+func TestUpdateYieldsToExistingLeader(t *testing.T) {
+	store := &memStore{}
+	leader := NewLeaderElectionCell[string]([]string{"leader"}, time.Minute)
+	if _, isLeader, _, err := leader.Update(context.Background(), store, "node-1"); err != nil || !isLeader {
+		t.Fatalf("node-1 failed to become leader: isLeader=%v err=%v", isLeader, err)
+	}
+
+	follower := NewLeaderElectionCell[string]([]string{"leader"}, time.Minute)
+	value, isLeader, _, err := follower.Update(context.Background(), store, "node-2")
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if isLeader {
+		t.Fatalf("node-2 should not have become leader while node-1 holds it")
+	}
+	if value != "node-1" {
+		t.Fatalf("expected observed leader %q, got %q", "node-1", value)
+	}
+}
+
+// TestRunOnLostReportsOwnValue guards against a regression where Run invoked OnLost with
+// the value of whichever competitor preempted this cell, instead of the value this cell
+// itself had been leading with.
+func TestRunOnLostReportsOwnValue(t *testing.T) {
+	store := &memStore{}
+	cell := NewLeaderElectionCell[string]([]string{"leader"}, time.Minute)
+
+	if _, isLeader, _, err := cell.Update(context.Background(), store, "node-1"); err != nil || !isLeader {
+		t.Fatalf("failed to become leader: isLeader=%v err=%v", isLeader, err)
+	}
+
+	// Simulate another process taking over, as if node-1 stalled past its TTL.
+	store.forceValue(t, "node-2")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lost := make(chan string, 1)
+	done := make(chan struct{})
+	go func() {
+		cell.Run(ctx, store, "node-1", Callbacks[string]{
+			OnLost: func(v string) { lost <- v },
+		})
+		close(done)
+	}()
+
+	select {
+	case v := <-lost:
+		if v != "node-1" {
+			t.Fatalf("OnLost should report this cell's own value %q, got %q", "node-1", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for OnLost")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after ctx was canceled")
+	}
+}
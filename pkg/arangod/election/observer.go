@@ -0,0 +1,85 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package election
+
+// MetricsRecorder records leader election signals for a LeaderElectionCell.
+type MetricsRecorder interface {
+	// LeaderAcquired is called every time the cell becomes the leader.
+	LeaderAcquired()
+	// LeaderLost is called every time the cell stops being the leader.
+	LeaderLost()
+	// UpdatePreconditionFailed is called every time an Update call loses a compare-and-swap
+	// race against another competing leader.
+	UpdatePreconditionFailed()
+	// TTLRemainingSeconds reports the number of seconds left before the current leader
+	// value (not necessarily this cell) expires.
+	TTLRemainingSeconds(seconds float64)
+	// IsLeader reports whether the cell currently considers itself the leader.
+	IsLeader(isLeader bool)
+}
+
+// Logger is the minimal structured logger used by election.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Error(err error, msg string, keysAndValues ...interface{})
+}
+
+// Observer bundles the observability hooks a LeaderElectionCell reports through. Each
+// field defaults to a no-op when left nil.
+type Observer struct {
+	Metrics MetricsRecorder
+	Logger  Logger
+}
+
+func (o Observer) orNoop() Observer {
+	if o.Metrics == nil {
+		o.Metrics = noopMetricsRecorder{}
+	}
+	if o.Logger == nil {
+		o.Logger = noopLogger{}
+	}
+	return o
+}
+
+type noopMetricsRecorder struct{}
+
+func (noopMetricsRecorder) LeaderAcquired()            {}
+func (noopMetricsRecorder) LeaderLost()                {}
+func (noopMetricsRecorder) UpdatePreconditionFailed()  {}
+func (noopMetricsRecorder) TTLRemainingSeconds(float64) {}
+func (noopMetricsRecorder) IsLeader(bool)              {}
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...interface{})        {}
+func (noopLogger) Error(error, string, ...interface{}) {}
+
+// Option configures optional behavior of a LeaderElectionCell created via
+// NewLeaderElectionCell.
+type Option[T comparable] func(*LeaderElectionCell[T])
+
+// WithObserver attaches an Observer to the cell, so metrics and logs are reported as it
+// tries to acquire and hold leadership.
+func WithObserver[T comparable](observer Observer) Option[T] {
+	return func(l *LeaderElectionCell[T]) {
+		l.observer = observer.orNoop()
+	}
+}
@@ -0,0 +1,156 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package election
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Callbacks are invoked from the single goroutine driving LeaderElectionCell.Run. For any
+// given Update cycle, OnLeaderChanged (if the observed value changed) is called before
+// OnAcquired/OnLost (if this cell's own leadership status changed).
+type Callbacks[T comparable] struct {
+	// OnAcquired is called when this cell becomes the leader, with the value it acquired
+	// leadership with.
+	OnAcquired func(value T)
+	// OnLost is called when this cell stops being the leader, whether by resigning,
+	// being preempted, or Run returning because ctx is done while still leading.
+	OnLost func(value T)
+	// OnLeaderChanged is called whenever the observed leader value changes, regardless of
+	// whether this cell gained, lost, or was never involved in the transition.
+	OnLeaderChanged func(old, new T)
+	// OnError is called when a call to Update fails. Run keeps retrying afterwards, unless
+	// ctx is done, in which case Run returns next.
+	OnError func(err error)
+}
+
+// Run drives Update in a loop, using the delay it returns to pace successive calls, and
+// invokes the relevant Callbacks hook after every cycle until ctx is done. If this cell is
+// leading when ctx is done, Resign is called and OnLost fires before Run returns.
+func (l *LeaderElectionCell[T]) Run(ctx context.Context, store Store, value T, callbacks Callbacks[T]) {
+	var (
+		haveCurrent bool
+		current     T
+		leading     = l.leading
+	)
+
+	for {
+		result, isLeader, delay, err := l.Update(ctx, store, value)
+		if err != nil {
+			if callbacks.OnError != nil {
+				callbacks.OnError(err)
+			}
+		} else {
+			if !haveCurrent || !reflect.DeepEqual(current, result) {
+				old := current
+				current = result
+				haveCurrent = true
+				if callbacks.OnLeaderChanged != nil {
+					callbacks.OnLeaderChanged(old, current)
+				}
+			}
+
+			if isLeader && !leading {
+				leading = true
+				if callbacks.OnAcquired != nil {
+					callbacks.OnAcquired(result)
+				}
+			} else if !isLeader && leading {
+				leading = false
+				if callbacks.OnLost != nil {
+					callbacks.OnLost(value)
+				}
+			}
+		}
+
+		if err != nil {
+			delay = minRetryDelay
+		}
+
+		select {
+		case <-ctx.Done():
+			if leading {
+				if err := l.Resign(context.Background(), store); err != nil && callbacks.OnError != nil {
+					callbacks.OnError(err)
+				}
+				leading = false
+				if callbacks.OnLost != nil {
+					callbacks.OnLost(value)
+				}
+			}
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// minRetryDelay paces retries of Run's loop after an Update error, so a persistently
+// failing Store does not spin the loop.
+const minRetryDelay = 500 * time.Millisecond
+
+// LeaderEvent is emitted on the channel returned by Watch whenever the observed leader
+// value changes, or this cell's own leadership status changes. Err is set, with Value and
+// IsLeader left at their zero value, when an Update call failed.
+type LeaderEvent[T comparable] struct {
+	Value    T
+	IsLeader bool
+	Err      error
+}
+
+// Watch runs the update loop internally, like Run, but reports transitions on the returned
+// channel instead of via callbacks, so a caller can select on it alongside other events.
+// The channel is closed, after any held leadership has been resigned, once ctx is done.
+func (l *LeaderElectionCell[T]) Watch(ctx context.Context, store Store, value T) <-chan LeaderEvent[T] {
+	ch := make(chan LeaderEvent[T])
+
+	go func() {
+		defer close(ch)
+
+		leading := l.leading
+		send := func(ev LeaderEvent[T]) {
+			select {
+			case ch <- ev:
+			case <-ctx.Done():
+			}
+		}
+
+		l.Run(ctx, store, value, Callbacks[T]{
+			OnAcquired: func(v T) {
+				leading = true
+				send(LeaderEvent[T]{Value: v, IsLeader: true})
+			},
+			OnLost: func(v T) {
+				leading = false
+				send(LeaderEvent[T]{Value: v, IsLeader: false})
+			},
+			OnLeaderChanged: func(_, new T) {
+				send(LeaderEvent[T]{Value: new, IsLeader: leading})
+			},
+			OnError: func(err error) {
+				send(LeaderEvent[T]{Err: err})
+			},
+		})
+	}()
+
+	return ch
+}
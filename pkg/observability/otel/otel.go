@@ -0,0 +1,169 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package otel implements conn.Tracer, and conn.MetricsRecorder/election.MetricsRecorder,
+// on top of the OpenTelemetry SDK, so a Connection and a LeaderElectionCell can be wired to
+// an OpenTelemetry pipeline without pulling in any other observability backend.
+package otel
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/arangodb-helper/go-helper/pkg/arangod/conn"
+	"github.com/arangodb-helper/go-helper/pkg/arangod/election"
+)
+
+// Tracer implements conn.Tracer on top of an OpenTelemetry trace.Tracer.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer creates a Tracer that starts spans on the given OpenTelemetry tracer.
+func NewTracer(tracer trace.Tracer) *Tracer {
+	return &Tracer{tracer: tracer}
+}
+
+func (t *Tracer) StartSpan(ctx context.Context, method, endpoint string) (context.Context, func(statusCode int, err error)) {
+	ctx, span := t.tracer.Start(ctx, method+" "+endpoint, trace.WithAttributes(
+		attribute.String("http.method", method),
+		attribute.String("http.endpoint", endpoint),
+	))
+
+	return ctx, func(statusCode int, err error) {
+		span.SetAttributes(attribute.Int("http.status_code", statusCode))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+var _ conn.Tracer = (*Tracer)(nil)
+
+// Recorder implements conn.MetricsRecorder and election.MetricsRecorder on top of
+// OpenTelemetry metric instruments.
+type Recorder struct {
+	requestsTotal       metric.Int64Counter
+	requestErrorsTotal  metric.Int64Counter
+	requestRetriesTotal metric.Int64Counter
+	requestDuration     metric.Float64Histogram
+
+	leaderAcquiredTotal     metric.Int64Counter
+	leaderLostTotal         metric.Int64Counter
+	preconditionFailedTotal metric.Int64Counter
+	ttlRemainingSeconds     metric.Float64Gauge
+	isLeader                metric.Float64Gauge
+}
+
+// NewRecorder creates a Recorder instrumenting itself against the given OpenTelemetry
+// meter.
+func NewRecorder(meter metric.Meter) (*Recorder, error) {
+	var (
+		r   Recorder
+		err error
+	)
+
+	if r.requestsTotal, err = meter.Int64Counter("requests_total"); err != nil {
+		return nil, err
+	}
+	if r.requestErrorsTotal, err = meter.Int64Counter("request_errors_total"); err != nil {
+		return nil, err
+	}
+	if r.requestRetriesTotal, err = meter.Int64Counter("request_retries_total"); err != nil {
+		return nil, err
+	}
+	if r.requestDuration, err = meter.Float64Histogram("request_duration_seconds"); err != nil {
+		return nil, err
+	}
+	if r.leaderAcquiredTotal, err = meter.Int64Counter("leader_acquired_total"); err != nil {
+		return nil, err
+	}
+	if r.leaderLostTotal, err = meter.Int64Counter("leader_lost_total"); err != nil {
+		return nil, err
+	}
+	if r.preconditionFailedTotal, err = meter.Int64Counter("update_precondition_failed_total"); err != nil {
+		return nil, err
+	}
+	if r.ttlRemainingSeconds, err = meter.Float64Gauge("ttl_remaining_seconds"); err != nil {
+		return nil, err
+	}
+	if r.isLeader, err = meter.Float64Gauge("is_leader"); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+func (r *Recorder) RequestCompleted(method, endpoint string, statusCode int, duration time.Duration) {
+	attrs := metric.WithAttributes(
+		attribute.String("method", method),
+		attribute.String("endpoint", endpoint),
+		attribute.Int("status", statusCode),
+	)
+	r.requestsTotal.Add(context.Background(), 1, attrs)
+	r.requestDuration.Record(context.Background(), duration.Seconds(), attrs)
+}
+
+func (r *Recorder) RequestFailed(method, endpoint string, duration time.Duration) {
+	attrs := metric.WithAttributes(attribute.String("method", method), attribute.String("endpoint", endpoint))
+	r.requestErrorsTotal.Add(context.Background(), 1, attrs)
+	r.requestDuration.Record(context.Background(), duration.Seconds(), attrs)
+}
+
+func (r *Recorder) RequestRetried(method, endpoint string) {
+	attrs := metric.WithAttributes(attribute.String("method", method), attribute.String("endpoint", endpoint))
+	r.requestRetriesTotal.Add(context.Background(), 1, attrs)
+}
+
+func (r *Recorder) LeaderAcquired() {
+	r.leaderAcquiredTotal.Add(context.Background(), 1)
+}
+
+func (r *Recorder) LeaderLost() {
+	r.leaderLostTotal.Add(context.Background(), 1)
+}
+
+func (r *Recorder) UpdatePreconditionFailed() {
+	r.preconditionFailedTotal.Add(context.Background(), 1)
+}
+
+func (r *Recorder) TTLRemainingSeconds(seconds float64) {
+	r.ttlRemainingSeconds.Record(context.Background(), seconds)
+}
+
+func (r *Recorder) IsLeader(isLeader bool) {
+	value := 0.0
+	if isLeader {
+		value = 1
+	}
+	r.isLeader.Record(context.Background(), value)
+}
+
+var (
+	_ conn.MetricsRecorder     = (*Recorder)(nil)
+	_ election.MetricsRecorder = (*Recorder)(nil)
+)
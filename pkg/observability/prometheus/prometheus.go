@@ -0,0 +1,145 @@
+//
+// DISCLAIMER
+//
+// Copyright 2023 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package prometheus implements conn.MetricsRecorder and election.MetricsRecorder on top
+// of Prometheus collectors, so a single Recorder can be shared between a Connection and a
+// LeaderElectionCell without pulling in any other metrics backend.
+package prometheus
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/arangodb-helper/go-helper/pkg/arangod/conn"
+	"github.com/arangodb-helper/go-helper/pkg/arangod/election"
+)
+
+// Recorder implements conn.MetricsRecorder and election.MetricsRecorder.
+type Recorder struct {
+	requestsTotal       *prometheus.CounterVec
+	requestErrorsTotal  *prometheus.CounterVec
+	requestRetriesTotal *prometheus.CounterVec
+	requestDuration     *prometheus.HistogramVec
+
+	leaderAcquiredTotal     prometheus.Counter
+	leaderLostTotal         prometheus.Counter
+	preconditionFailedTotal prometheus.Counter
+	ttlRemainingSeconds     prometheus.Gauge
+	isLeader                prometheus.Gauge
+}
+
+// NewRecorder creates a Recorder and registers its collectors with reg under namespace.
+func NewRecorder(namespace string, reg prometheus.Registerer) *Recorder {
+	r := &Recorder{
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "requests_total", Help: "Number of requests completed, by method and status code.",
+		}, []string{"method", "status"}),
+		requestErrorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "request_errors_total", Help: "Number of requests that failed before a response was received, by method.",
+		}, []string{"method"}),
+		requestRetriesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "request_retries_total", Help: "Number of requests retried after a 401 response, by method.",
+		}, []string{"method"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Name: "request_duration_seconds", Help: "Request duration in seconds, by method.",
+		}, []string{"method"}),
+		leaderAcquiredTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Name: "leader_acquired_total", Help: "Number of times leadership was acquired.",
+		}),
+		leaderLostTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Name: "leader_lost_total", Help: "Number of times leadership was lost.",
+		}),
+		preconditionFailedTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Name: "update_precondition_failed_total", Help: "Number of times an Update call lost a compare-and-swap race.",
+		}),
+		ttlRemainingSeconds: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "ttl_remaining_seconds", Help: "Seconds remaining before the current leader value expires.",
+		}),
+		isLeader: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "is_leader", Help: "1 if this process currently holds leadership, 0 otherwise.",
+		}),
+	}
+
+	reg.MustRegister(
+		r.requestsTotal,
+		r.requestErrorsTotal,
+		r.requestRetriesTotal,
+		r.requestDuration,
+		r.leaderAcquiredTotal,
+		r.leaderLostTotal,
+		r.preconditionFailedTotal,
+		r.ttlRemainingSeconds,
+		r.isLeader,
+	)
+
+	return r
+}
+
+// RequestCompleted, RequestFailed and RequestRetried deliberately drop endpoint from their
+// Prometheus labels even though conn.MetricsRecorder passes it: endpoint is the literal
+// request path (e.g. "/_api/document/<collection>/<key>"), and client_golang vectors never
+// evict cached children, so labeling by it would leak an unbounded, ever-growing set of
+// metric series in any long-running process. Use the otel Tracer, which attaches endpoint
+// to per-request span attributes instead, if per-request granularity is needed.
+
+func (r *Recorder) RequestCompleted(method, _ string, statusCode int, duration time.Duration) {
+	r.requestsTotal.WithLabelValues(method, strconv.Itoa(statusCode)).Inc()
+	r.requestDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func (r *Recorder) RequestFailed(method, _ string, duration time.Duration) {
+	r.requestErrorsTotal.WithLabelValues(method).Inc()
+	r.requestDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func (r *Recorder) RequestRetried(method, _ string) {
+	r.requestRetriesTotal.WithLabelValues(method).Inc()
+}
+
+func (r *Recorder) LeaderAcquired() {
+	r.leaderAcquiredTotal.Inc()
+}
+
+func (r *Recorder) LeaderLost() {
+	r.leaderLostTotal.Inc()
+}
+
+func (r *Recorder) UpdatePreconditionFailed() {
+	r.preconditionFailedTotal.Inc()
+}
+
+func (r *Recorder) TTLRemainingSeconds(seconds float64) {
+	r.ttlRemainingSeconds.Set(seconds)
+}
+
+func (r *Recorder) IsLeader(isLeader bool) {
+	if isLeader {
+		r.isLeader.Set(1)
+	} else {
+		r.isLeader.Set(0)
+	}
+}
+
+var (
+	_ conn.MetricsRecorder     = (*Recorder)(nil)
+	_ election.MetricsRecorder = (*Recorder)(nil)
+)